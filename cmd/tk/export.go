@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+func (a *App) exportCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export entries as CSV, JSON or iCalendar",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "csv",
+				Usage: "Output format: csv, json or ics",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Write to this file instead of stdout",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only export entries starting on or after this time",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only export entries starting before this time",
+			},
+			&cli.StringFlag{
+				Name:  "project",
+				Usage: "Only export entries for this project ref",
+			},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			var since, until time.Time
+			var err error
+			if s := command.String("since"); s != "" {
+				if since, err = parseLogTime(s); err != nil {
+					return fmt.Errorf("parse since: %w", err)
+				}
+			}
+			if s := command.String("until"); s != "" {
+				if until, err = parseLogTime(s); err != nil {
+					return fmt.Errorf("parse until: %w", err)
+				}
+			}
+
+			var projectFilter *int
+			if s := command.String("project"); s != "" {
+				ref, err := strconv.Atoi(s)
+				if err != nil {
+					return fmt.Errorf("parse project ref: %w", err)
+				}
+				projectFilter = &ref
+			}
+
+			var entries []exportEntry
+			for _, p := range a.DB.ListProjects(true) {
+				if projectFilter != nil && p.Ref != *projectFilter && p.ID != *projectFilter {
+					continue
+				}
+				for i, e := range p.AllEntries() {
+					if !since.IsZero() && e.Start.Before(since) {
+						continue
+					}
+					if !until.IsZero() && !e.Start.Before(until) {
+						continue
+					}
+					entries = append(entries, exportEntry{Project: p, Entry: e, Index: i})
+				}
+			}
+
+			w := io.Writer(os.Stdout)
+			if path := command.String("output"); path != "" {
+				f, err := os.Create(path)
+				if err != nil {
+					return fmt.Errorf("create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			switch format := command.String("format"); format {
+			case "csv":
+				return writeCSV(w, entries)
+			case "json":
+				return writeJSON(w, entries)
+			case "ics":
+				return writeICS(w, entries)
+			default:
+				return fmt.Errorf("invalid format %q (want csv, json or ics)", format)
+			}
+		},
+	}
+}
+
+// exportEntry pairs an entry with the project it belongs to and its index within that project's
+// chronological entry list, used to build a stable iCalendar UID.
+type exportEntry struct {
+	Project *Project
+	Entry   Entry
+	Index   int
+}
+
+func writeCSV(w io.Writer, entries []exportEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"project", "ref", "start", "end", "duration_seconds", "description", "tags"}); err != nil {
+		return err
+	}
+	for _, ee := range entries {
+		end := ""
+		if !ee.Entry.End.IsZero() {
+			end = ee.Entry.End.Format(time.RFC3339)
+		}
+		if err := cw.Write([]string{
+			ee.Project.Name,
+			strconv.Itoa(ee.Project.Ref),
+			ee.Entry.Start.Format(time.RFC3339),
+			end,
+			strconv.Itoa(int(ee.Entry.Duration().Seconds())),
+			ee.Entry.Description,
+			strings.Join(ee.Entry.Tags, ","),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type jsonEntry struct {
+	Project         string     `json:"project"`
+	Ref             int        `json:"ref"`
+	Start           time.Time  `json:"start"`
+	End             *time.Time `json:"end,omitempty"`
+	DurationSeconds int        `json:"duration_seconds"`
+	Description     string     `json:"description,omitempty"`
+	Tags            []string   `json:"tags,omitempty"`
+}
+
+func writeJSON(w io.Writer, entries []exportEntry) error {
+	out := make([]jsonEntry, len(entries))
+	for i, ee := range entries {
+		je := jsonEntry{
+			Project:         ee.Project.Name,
+			Ref:             ee.Project.Ref,
+			Start:           ee.Entry.Start,
+			DurationSeconds: int(ee.Entry.Duration().Seconds()),
+			Description:     ee.Entry.Description,
+			Tags:            ee.Entry.Tags,
+		}
+		if !ee.Entry.End.IsZero() {
+			end := ee.Entry.End
+			je.End = &end
+		}
+		out[i] = je
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// writeICS emits one VEVENT per completed entry (entries still in progress have no end time and
+// are skipped).
+func writeICS(w io.Writer, entries []exportEntry) error {
+	fmt.Fprintln(w, "BEGIN:VCALENDAR")
+	fmt.Fprintln(w, "VERSION:2.0")
+	fmt.Fprintln(w, "PRODID:-//timekeeper//tk export//EN")
+	for _, ee := range entries {
+		if ee.Entry.End.IsZero() {
+			continue
+		}
+		fmt.Fprintln(w, "BEGIN:VEVENT")
+		fmt.Fprintf(w, "UID:entry-%d-%d@timekeeper\n", ee.Project.ID, ee.Index)
+		fmt.Fprintf(w, "DTSTART:%s\n", icsTime(ee.Entry.Start))
+		fmt.Fprintf(w, "DTEND:%s\n", icsTime(ee.Entry.End))
+		fmt.Fprintf(w, "SUMMARY:%s\n", ee.Project.Name)
+		if ee.Entry.Description != "" {
+			fmt.Fprintf(w, "DESCRIPTION:%s\n", ee.Entry.Description)
+		}
+		if len(ee.Entry.Tags) > 0 {
+			fmt.Fprintf(w, "CATEGORIES:%s\n", strings.Join(ee.Entry.Tags, ","))
+		}
+		fmt.Fprintln(w, "END:VEVENT")
+	}
+	fmt.Fprintln(w, "END:VCALENDAR")
+	return nil
+}
+
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
@@ -2,12 +2,10 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -52,26 +50,21 @@ func main() {
 }
 
 func run(ctx context.Context) error {
-	db, err := readDB()
+	store, err := newStore()
 	if err != nil {
-		return fmt.Errorf("read db: %w", err)
+		return fmt.Errorf("open store: %w", err)
 	}
+	defer store.Close()
 
-	// Clone the DB so that we can compare it later to see if it changed.
-	a := &App{DB: db.Clone()}
-
-	rootCmd := a.createRootCmd()
-	if err := rootCmd.Run(ctx, os.Args); err != nil {
-		return err
+	db, err := store.LoadProjects()
+	if err != nil {
+		return fmt.Errorf("load projects: %w", err)
 	}
 
-	if !db.Equal(a.DB) {
-		if err := writeDB(a.DB); err != nil {
-			return fmt.Errorf("write db: %w", err)
-		}
-	}
+	a := &App{DB: db, Store: store}
 
-	return nil
+	rootCmd := a.createRootCmd()
+	return rootCmd.Run(ctx, os.Args)
 }
 
 func (a *App) createRootCmd() *cli.Command {
@@ -86,6 +79,15 @@ func (a *App) createRootCmd() *cli.Command {
 			a.archiveCmd(),
 			a.removeCmd(),
 			a.weekCmd(),
+			a.taskCmd(),
+			a.noteCmd(),
+			a.logCmd(),
+			a.migrateCmd(),
+			a.reportCmd(),
+			a.editCmd(),
+			a.addCmd(),
+			a.exportCmd(),
+			a.tuiCmd(),
 		},
 		Action: func(ctx context.Context, command *cli.Command) error {
 			if len(a.DB.Projects) == 0 {
@@ -108,7 +110,10 @@ func (a *App) newCmd() *cli.Command {
 				return errors.New("missing project name")
 			}
 
-			p := a.CreateProject(name)
+			p, err := a.CreateProject(name)
+			if err != nil {
+				return err
+			}
 			fmt.Printf("Created %s %s\n", p.Name, p.prettyRefParen())
 			return nil
 		},
@@ -119,6 +124,22 @@ func (a *App) stopCmd() *cli.Command {
 	return &cli.Command{
 		Name:  "stop",
 		Usage: "Stop the current project",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "message",
+				Aliases: []string{"m"},
+				Usage:   "Description for the entry being stopped",
+			},
+			&cli.StringFlag{
+				Name:    "tag",
+				Aliases: []string{"t"},
+				Usage:   "Comma-separated tags for the entry being stopped",
+			},
+			&cli.StringFlag{
+				Name:  "at",
+				Usage: "Stop time instead of now (15:04, \"2006-01-02 15:04\", RFC3339, or relative like \"-10m\")",
+			},
+		},
 		Action: func(ctx context.Context, command *cli.Command) error {
 			p, ok := a.InProgressProject()
 			if !ok {
@@ -126,8 +147,22 @@ func (a *App) stopCmd() *cli.Command {
 				return nil
 			}
 
-			a.Stop(p)
-			renderStopped(p)
+			at := time.Now()
+			if s := command.String("at"); s != "" {
+				var err error
+				if at, err = parseFlexTime(s, time.Now()); err != nil {
+					return fmt.Errorf("parse at: %w", err)
+				}
+			}
+
+			t, _, err := a.Stop(p, EntryMeta{
+				Description: command.String("message"),
+				Tags:        splitTags(command.String("tag")),
+			}, at)
+			if err != nil {
+				return err
+			}
+			renderStopped(p, t)
 
 			return nil
 		},
@@ -138,33 +173,47 @@ func (a *App) startCmd() *cli.Command {
 	return &cli.Command{
 		Name:  "start",
 		Usage: "Start the current project, or create a new one",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "at",
+				Usage: "Start time instead of now (15:04, \"2006-01-02 15:04\", RFC3339, or relative like \"-10m\")",
+			},
+		},
 		Action: func(ctx context.Context, command *cli.Command) error {
-			name := command.Args().First()
-			ref, err := strconv.Atoi(name)
-			if err == nil {
-				name = ""
-			}
-
-			p, err := a.GetOrCreateProject(ref, name)
+			p, t, err := a.ResolveStartTarget(command.Args().First())
 			if err != nil {
 				return err
 			}
 
-			// Stop the currently in-progress project, if any. There can only ever be at most one project in
-			// progress at a time. So, this could well be the same project as the one we're about to start.
+			at := time.Now()
+			if s := command.String("at"); s != "" {
+				if at, err = parseFlexTime(s, time.Now()); err != nil {
+					return fmt.Errorf("parse at: %w", err)
+				}
+			}
+
+			// Stop the currently in-progress project, if any. There can only ever be at most one project (and
+			// task) in progress at a time. So, this could well be the same project/task as the one we're about
+			// to start.
 			if ip, ok := a.InProgressProject(); ok {
-				if ip.ID == p.ID {
+				ipTask, _ := ip.InProgressTask()
+				if ip.ID == p.ID && taskEqual(ipTask, t) {
 					fmt.Println("Project already in progress")
 					return nil
 				}
 
-				a.Stop(ip)
-				renderStopped(ip)
+				stopped, _, err := a.Stop(ip, EntryMeta{}, at)
+				if err != nil {
+					return err
+				}
+				renderStopped(ip, stopped)
 				fmt.Println()
 			}
 
-			a.Start(p)
-			renderStarted(p)
+			if err := a.Start(p, t, at); err != nil {
+				return err
+			}
+			renderStarted(p, t)
 
 			return nil
 		},
@@ -176,30 +225,369 @@ func (a *App) startStopCmd() *cli.Command {
 		Name:  "s",
 		Usage: "Context-aware start/stop",
 		Action: func(ctx context.Context, command *cli.Command) error {
-			name := command.Args().First()
-			ref, err := strconv.Atoi(name)
-			if err == nil {
-				name = ""
+			p, t, err := a.ResolveStartTarget(command.Args().First())
+			if err != nil {
+				return err
+			}
+
+			// Stop the currently in-progress project, if any. There can only ever be at most one project (and
+			// task) in progress at a time. So, this could well be the same project/task as the one we're about
+			// to start.
+			if ip, ok := a.InProgressProject(); ok {
+				stopped, didStop, err := a.Stop(ip, EntryMeta{}, time.Now())
+				if err != nil {
+					return err
+				}
+				if didStop {
+					renderStopped(ip, stopped)
+					if ip.ID == p.ID && taskEqual(stopped, t) {
+						return nil
+					}
+					fmt.Println()
+				}
+			}
+
+			if err := a.Start(p, t, time.Now()); err != nil {
+				return err
+			}
+			renderStarted(p, t)
+
+			return nil
+		},
+	}
+}
+
+func (a *App) taskCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "task",
+		Usage: "Manage tasks within a project",
+		Commands: []*cli.Command{
+			a.taskNewCmd(),
+		},
+	}
+}
+
+func (a *App) taskNewCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "new",
+		Usage: "Create a new task under a project",
+		Action: func(ctx context.Context, command *cli.Command) error {
+			if command.NArg() < 2 {
+				return errors.New("usage: tk task new <project-ref> <name>")
+			}
+
+			ref, err := strconv.Atoi(command.Args().Get(0))
+			if err != nil {
+				return fmt.Errorf("parse project ref: %w", err)
 			}
 
-			p, err := a.GetOrCreateProject(ref, name)
+			p, err := a.ProjectByRef(ref)
 			if err != nil {
 				return err
 			}
 
-			// Stop the currently in-progress project, if any. There can only ever be at most one project in
-			// progress at a time. So, this could well be the same project as the one we're about to start.
-			if ip, ok := a.InProgressProject(); ok && a.Stop(ip) {
-				renderStopped(ip)
-				if p.ID == ip.ID {
-					return nil
+			t, err := a.GetOrCreateTask(p, command.Args().Get(1))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Created task %s under %s %s\n", t.Name, p.Name, p.prettyRefParen())
+
+			return nil
+		},
+	}
+}
+
+func (a *App) editCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "edit",
+		Usage: "Edit the start and/or end time of an existing entry",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "entry",
+				Value: -1,
+				Usage: "Index of the entry to edit, starting from 0; defaults to the most recent",
+			},
+			&cli.StringFlag{
+				Name:  "start",
+				Usage: "New start time for the entry",
+			},
+			&cli.StringFlag{
+				Name:  "end",
+				Usage: "New end time for the entry",
+			},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			p, t, err := a.resolveProjectTask(command.Args().First())
+			if err != nil {
+				return err
+			}
+
+			entries := p.Entries
+			if t != nil {
+				entries = t.Entries
+			}
+			if len(entries) == 0 {
+				return errors.New("no entries to edit")
+			}
+
+			index := command.Int("entry")
+			if index < 0 {
+				index = len(entries) - 1
+			}
+			if int(index) >= len(entries) {
+				return fmt.Errorf("entry %d not found", index)
+			}
+
+			e := entries[index]
+			now := time.Now()
+			if s := command.String("start"); s != "" {
+				if e.Start, err = parseFlexTime(s, now); err != nil {
+					return fmt.Errorf("parse start: %w", err)
 				}
-				fmt.Println()
 			}
+			if s := command.String("end"); s != "" {
+				if e.End, err = parseFlexTime(s, now); err != nil {
+					return fmt.Errorf("parse end: %w", err)
+				}
+			}
+
+			if err := a.EditEntry(p, t, int(index), e.Start, e.End); err != nil {
+				return err
+			}
+			fmt.Printf("Updated entry %d for %s %s: %s - %s\n", index, taskLabel(p, t), p.prettyRefParen(),
+				e.Start.Format("2006-01-02 15:04"), formatEntryEnd(e))
+
+			return nil
+		},
+	}
+}
+
+func (a *App) addCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "add",
+		Usage: "Add a completed entry after the fact",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "start",
+				Usage:    "Start time for the entry",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "end",
+				Usage:    "End time for the entry",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:    "message",
+				Aliases: []string{"m"},
+				Usage:   "Description for the entry",
+			},
+			&cli.StringFlag{
+				Name:    "tag",
+				Aliases: []string{"t"},
+				Usage:   "Comma-separated tags for the entry",
+			},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			p, t, err := a.resolveProjectTask(command.Args().First())
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			start, err := parseFlexTime(command.String("start"), now)
+			if err != nil {
+				return fmt.Errorf("parse start: %w", err)
+			}
+			end, err := parseFlexTime(command.String("end"), now)
+			if err != nil {
+				return fmt.Errorf("parse end: %w", err)
+			}
+
+			meta := EntryMeta{
+				Description: command.String("message"),
+				Tags:        splitTags(command.String("tag")),
+			}
+			if err := a.AddEntry(p, t, start, end, meta); err != nil {
+				return err
+			}
+			fmt.Printf("Added entry for %s %s: %s - %s\n", taskLabel(p, t), p.prettyRefParen(),
+				start.Format("2006-01-02 15:04"), end.Format("2006-01-02 15:04"))
+
+			return nil
+		},
+	}
+}
+
+func (a *App) noteCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "note",
+		Usage: "Add a description to the currently running entry",
+		Action: func(ctx context.Context, command *cli.Command) error {
+			p, ok := a.InProgressProject()
+			if !ok {
+				fmt.Println("No project in progress")
+				return nil
+			}
+
+			desc := strings.Join(command.Args().Slice(), " ")
+			if desc == "" {
+				return errors.New("missing note text")
+			}
+
+			if err := a.SetInProgressDescription(p, desc); err != nil {
+				return err
+			}
+			fmt.Printf("Noted: %s\n", desc)
+
+			return nil
+		},
+	}
+}
+
+func (a *App) logCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "log",
+		Usage: "Show individual entries for a project",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only show entries starting on or after this time",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only show entries starting before this time",
+			},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			ref, err := strconv.Atoi(command.Args().First())
+			if err != nil {
+				return fmt.Errorf("parse project ref: %w", err)
+			}
+			p, err := a.ProjectByRef(ref)
+			if err != nil {
+				return err
+			}
+
+			var since, until time.Time
+			if s := command.String("since"); s != "" {
+				if since, err = parseLogTime(s); err != nil {
+					return fmt.Errorf("parse since: %w", err)
+				}
+			}
+			if s := command.String("until"); s != "" {
+				if until, err = parseLogTime(s); err != nil {
+					return fmt.Errorf("parse until: %w", err)
+				}
+			}
+
+			header := []string{"Start", "End", "Duration", "Description", "Tags"}
+			for i, h := range header {
+				header[i] = white.Render(h)
+			}
+			rows := [][]string{header}
+			for _, e := range p.AllEntries() {
+				if !since.IsZero() && e.Start.Before(since) {
+					continue
+				}
+				if !until.IsZero() && !e.Start.Before(until) {
+					continue
+				}
+
+				rows = append(rows, []string{
+					e.Start.Format("2006-01-02 15:04"),
+					formatEntryEnd(e),
+					formatDuration(e.Duration()),
+					e.Description,
+					strings.Join(e.Tags, ","),
+				})
+			}
+
+			fmt.Println(grid(rows...))
+			return nil
+		},
+	}
+}
+
+func parseLogTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// parseFlexTime parses a timestamp for manual entry editing/backdating. It accepts RFC3339,
+// "2006-01-02 15:04", a bare "15:04" (combined with now's date), a duration relative to now
+// (e.g. "-10m"), and "<duration> ago" (e.g. "1h ago").
+func parseFlexTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02 15:04", s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("15:04", s); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+	}
+	if rest, ok := strings.CutSuffix(s, " ago"); ok {
+		dur, err := time.ParseDuration(rest)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q: %w", s, err)
+		}
+		return now.Add(-dur), nil
+	}
+	if dur, err := time.ParseDuration(s); err == nil {
+		return now.Add(dur), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+func (a *App) migrateCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Migrate the JSON database file to SQLite",
+		Action: func(ctx context.Context, command *cli.Command) error {
+			jsonPath, err := getDBPath()
+			if err != nil {
+				return err
+			}
+			db, err := (&jsonStore{path: jsonPath}).LoadProjects()
+			if err != nil {
+				return fmt.Errorf("load json db: %w", err)
+			}
+
+			dsn, err := getSQLiteDSN()
+			if err != nil {
+				return err
+			}
+			dst, err := newSQLiteStore(dsn)
+			if err != nil {
+				return fmt.Errorf("open sqlite db: %w", err)
+			}
+			defer dst.Close()
 
-			a.Start(p)
-			renderStarted(p)
+			for _, p := range db.Projects {
+				if err := dst.SaveProject(p); err != nil {
+					return fmt.Errorf("migrate project %s: %w", p.Name, err)
+				}
+				for i := range p.Entries {
+					if err := dst.AppendEntry(p, nil, &p.Entries[i]); err != nil {
+						return fmt.Errorf("migrate entry for %s: %w", p.Name, err)
+					}
+				}
+				for _, t := range p.Tasks {
+					for i := range t.Entries {
+						if err := dst.AppendEntry(p, t, &t.Entries[i]); err != nil {
+							return fmt.Errorf("migrate entry for %s:%s: %w", p.Name, t.Name, err)
+						}
+					}
+				}
+			}
 
+			fmt.Printf("Migrated %d project(s) to %s\n", len(db.Projects), dsn)
 			return nil
 		},
 	}
@@ -226,17 +614,48 @@ func (a *App) listCmd() *cli.Command {
 				Value: 15,
 				Usage: "List the first n projects",
 			},
+			&cli.StringFlag{
+				Name:  "tag",
+				Usage: "Only list projects with at least one entry matching this tag",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Show a Range column totalling entries starting on or after this time",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Show a Range column totalling entries starting before this time",
+			},
+			&cli.StringFlag{
+				Name:  "period",
+				Usage: "Show a Range column totalling entries in this period (day, week, month, year)",
+			},
 		},
 		Action: func(ctx context.Context, command *cli.Command) error {
 			all := command.Bool("all")
 			allArchived := command.Bool("all-archived")
 			n := command.Int("n")
+			tag := command.String("tag")
 			projects := a.DB.ListProjects(allArchived)
 			if len(projects) == 0 {
 				fmt.Println("No projects")
 				return nil
 			}
+
+			rangeGiven := command.String("since") != "" || command.String("until") != "" || command.String("period") != ""
+			var rangeStart, rangeEnd time.Time
+			if rangeGiven {
+				var err error
+				rangeStart, rangeEnd, err = resolveRange(command, time.Time{}, farFuture())
+				if err != nil {
+					return err
+				}
+			}
+
 			header := []string{"Ref", "Name", "Last Start", "Last Duration", "This Week", "Total"}
+			if rangeGiven {
+				header[4] = "Range"
+			}
 			if allArchived {
 				header = append(header, "Archived")
 			}
@@ -245,13 +664,20 @@ func (a *App) listCmd() *cli.Command {
 			}
 			rows := [][]string{header}
 			for _, p := range projects {
+				if tag != "" && !p.HasTag(tag) {
+					continue
+				}
+				periodCol := p.ThisWeekFormatted()
+				if rangeGiven {
+					periodCol = cyan.Render(formatDuration(p.DurationBetween(rangeStart, rangeEnd)))
+				}
 				if p.Archived {
 					rows = append(rows, []string{
 						p.prettyRef(),
 						grey.Render(p.Name),
 						grey.Render(p.LastStartFormatted()),
 						grey.Render(p.DurationFormatted()),
-						grey.Render(p.ThisWeekFormatted()),
+						grey.Render(periodCol),
 						grey.Render(p.TotalFormatted()),
 						grey.Render("True"),
 					})
@@ -265,10 +691,28 @@ func (a *App) listCmd() *cli.Command {
 						name,
 						grey.Render(p.LastStartFormatted()),
 						grey.Render(p.DurationFormatted()),
-						grey.Render(p.ThisWeekFormatted()),
+						grey.Render(periodCol),
 						grey.Render(p.TotalFormatted()),
 					})
 				}
+				for _, t := range p.Tasks {
+					taskPeriod := t.ThisWeek()
+					if rangeGiven {
+						taskPeriod = t.DurationBetween(rangeStart, rangeEnd)
+					}
+					taskRow := []string{
+						"",
+						grey.Render("  " + t.Name),
+						grey.Render("-"),
+						grey.Render("-"),
+						grey.Render(formatDuration(taskPeriod)),
+						grey.Render(formatDuration(t.Total())),
+					}
+					if allArchived {
+						taskRow = append(taskRow, "")
+					}
+					rows = append(rows, taskRow)
+				}
 				if !all && !allArchived && len(rows) > int(n) {
 					break
 				}
@@ -306,12 +750,18 @@ func (a *App) archiveCmd() *cli.Command {
 				}
 
 				if !p.Archived && p.InProgress() {
-					a.Stop(p)
-					renderStopped(p)
+					t, _, err := a.Stop(p, EntryMeta{}, time.Now())
+					if err != nil {
+						return err
+					}
+					renderStopped(p, t)
 					fmt.Println()
 				}
 
 				p.Archived = !p.Archived
+				if err := a.Store.SaveProject(p); err != nil {
+					return fmt.Errorf("save project: %w", err)
+				}
 				if p.Archived {
 					fmt.Printf("%s %s %s\n", grey.Render("Archived:"), p.Name, p.prettyRefParen())
 				} else {
@@ -363,6 +813,9 @@ func (a *App) removeCmd() *cli.Command {
 				}
 
 				a.DB.RemoveProject(p)
+				if err := a.Store.RemoveProject(p); err != nil {
+					return fmt.Errorf("remove project: %w", err)
+				}
 				fmt.Printf("%s %s %s\n", red.Render("Removed:"), p.Name, p.prettyRefParen())
 			}
 
@@ -375,6 +828,20 @@ func (a *App) weekCmd() *cli.Command {
 	return &cli.Command{
 		Name:  "week",
 		Usage: "Show a summary of the current week",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only include entries starting on or after this time",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only include entries starting before this time",
+			},
+			&cli.StringFlag{
+				Name:  "period",
+				Usage: "Use a fixed period instead of the current week (day, week, month, year)",
+			},
+		},
 		Action: func(ctx context.Context, command *cli.Command) error {
 			projects := a.DB.ListProjects(false)
 			if len(projects) == 0 {
@@ -382,41 +849,271 @@ func (a *App) weekCmd() *cli.Command {
 				return nil
 			}
 
+			defaultStart, defaultEnd := weekBounds(time.Now())
+			start, end, err := resolveRange(command, defaultStart, defaultEnd)
+			if err != nil {
+				return err
+			}
+
 			header := []string{"Name", "This Week", "Total"}
+			if command.String("since") != "" || command.String("until") != "" || command.String("period") != "" {
+				header[1] = "Range"
+			}
 			for i, h := range header {
 				header[i] = white.Render(h)
 			}
 			rows := [][]string{header}
 			for _, p := range projects {
-				thisWeek := p.ThisWeek()
-				if thisWeek == 0 {
+				periodTotal := p.DurationBetween(start, end)
+				if periodTotal == 0 {
 					continue
 				}
 				rows = append(rows, []string{
 					p.Name,
-					cyan.Render(formatDuration(thisWeek)),
+					cyan.Render(formatDuration(periodTotal)),
 					cyan.Render(p.TotalFormatted()),
 				})
-			}
-
+				for _, t := range p.Tasks {
+					taskTotal := t.DurationBetween(start, end)
+					if taskTotal == 0 {
+						continue
+					}
+					rows = append(rows, []string{
+						grey.Render("  " + t.Name),
+						grey.Render(formatDuration(taskTotal)),
+						grey.Render(formatDuration(t.Total())),
+					})
+				}
+			}
+
+			fmt.Println(grid(rows...))
+			return nil
+		},
+	}
+}
+
+// resolveRange resolves the --since/--until/--period flags on command into a time range,
+// falling back to (defaultStart, defaultEnd) if none of them were given.
+func resolveRange(command *cli.Command, defaultStart, defaultEnd time.Time) (time.Time, time.Time, error) {
+	if period := command.String("period"); period != "" {
+		return periodBounds(period, time.Now())
+	}
+
+	since := command.String("since")
+	until := command.String("until")
+	if since == "" && until == "" {
+		return defaultStart, defaultEnd, nil
+	}
+
+	start, end := defaultStart, defaultEnd
+	if since != "" {
+		parsed, err := parseLogTime(since)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parse since: %w", err)
+		}
+		start = parsed
+	}
+	if until != "" {
+		parsed, err := parseLogTime(until)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parse until: %w", err)
+		}
+		end = parsed
+	}
+	return start, end, nil
+}
+
+// periodBounds returns the start (inclusive) and end (exclusive) of the named period,
+// anchored on now.
+func periodBounds(period string, now time.Time) (time.Time, time.Time, error) {
+	switch period {
+	case "day":
+		start := truncateDay(now)
+		return start, start.AddDate(0, 0, 1), nil
+	case "week":
+		start, end := weekBounds(now)
+		return start, end, nil
+	case "month":
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 1, 0), nil
+	case "year":
+		start := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(1, 0, 0), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid period %q (want day, week, month or year)", period)
+	}
+}
+
+// truncateDay returns t with its time-of-day component zeroed out.
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// farFuture returns a time far enough ahead to act as an open-ended upper bound on a range.
+func farFuture() time.Time {
+	return time.Now().AddDate(100, 0, 0)
+}
+
+func (a *App) reportCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "Show totals for an arbitrary period",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "daily",
+				Usage: "Break the total down by day",
+			},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			periodArg := command.Args().First()
+			if periodArg == "" {
+				periodArg = "this-week"
+			}
+
+			start, end, err := parseReportPeriod(periodArg, time.Now())
+			if err != nil {
+				return err
+			}
+
+			projects := a.DB.ListProjects(false)
+
+			header := []string{"Name", "Total"}
+			for i, h := range header {
+				header[i] = white.Render(h)
+			}
+			rows := [][]string{header}
+			var grandTotal time.Duration
+			for _, p := range projects {
+				total := p.DurationBetween(start, end)
+				if total == 0 {
+					continue
+				}
+				grandTotal += total
+				rows = append(rows, []string{p.Name, cyan.Render(formatDuration(total))})
+				for _, t := range p.Tasks {
+					taskTotal := t.DurationBetween(start, end)
+					if taskTotal == 0 {
+						continue
+					}
+					rows = append(rows, []string{grey.Render("  " + t.Name), grey.Render(formatDuration(taskTotal))})
+				}
+			}
+			if len(rows) == 1 {
+				fmt.Println("No entries in this period")
+				return nil
+			}
+			rows = append(rows, []string{white.Render("Total"), cyan.Render(formatDuration(grandTotal))})
+
 			fmt.Println(grid(rows...))
+
+			if command.Bool("daily") {
+				fmt.Println()
+				renderDailyBreakdown(projects, start, end)
+			}
+
 			return nil
 		},
 	}
 }
 
+// renderDailyBreakdown prints one row per day in [start, end) with the combined duration of
+// every project's entries (including tasks) starting that day.
+func renderDailyBreakdown(projects []*Project, start, end time.Time) {
+	header := []string{"Day", "Total"}
+	for i, h := range header {
+		header[i] = white.Render(h)
+	}
+	rows := [][]string{header}
+	for day := truncateDay(start); day.Before(end); day = day.AddDate(0, 0, 1) {
+		dayEnd := day.AddDate(0, 0, 1)
+		var total time.Duration
+		for _, p := range projects {
+			total += p.DurationBetween(day, dayEnd)
+		}
+		rows = append(rows, []string{
+			grey.Render(day.Format("Mon 2006-01-02")),
+			cyan.Render(formatDuration(total)),
+		})
+	}
+	fmt.Println(grid(rows...))
+}
+
+// parseReportPeriod parses the period argument to `tk report` into a [start, end) range anchored
+// on now. It accepts the named periods "today", "yesterday", "this-week" and "last-week", ISO
+// week strings like "2024-W23", year-month strings like "2024-06", and anything parseLogTime
+// understands (treated as a single day).
+func parseReportPeriod(s string, now time.Time) (time.Time, time.Time, error) {
+	switch s {
+	case "today":
+		start := truncateDay(now)
+		return start, start.AddDate(0, 0, 1), nil
+	case "yesterday":
+		start := truncateDay(now.AddDate(0, 0, -1))
+		return start, start.AddDate(0, 0, 1), nil
+	case "this-week":
+		start, end := weekBounds(now)
+		return start, end, nil
+	case "last-week":
+		start, end := weekBounds(now.AddDate(0, 0, -7))
+		return start, end, nil
+	}
+
+	if year, week, ok := parseISOWeek(s); ok {
+		start := isoWeekStart(year, week)
+		return start, start.AddDate(0, 0, 7), nil
+	}
+
+	if t, err := time.Parse("2006-01", s); err == nil {
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 1, 0), nil
+	}
+
+	if t, err := parseLogTime(s); err == nil {
+		start := truncateDay(t)
+		return start, start.AddDate(0, 0, 1), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid period %q", s)
+}
+
+// parseISOWeek parses strings of the form "2024-W23" into a year and ISO week number.
+func parseISOWeek(s string) (year, week int, ok bool) {
+	yearPart, weekPart, found := strings.Cut(s, "-W")
+	if !found {
+		return 0, 0, false
+	}
+	y, err := strconv.Atoi(yearPart)
+	if err != nil {
+		return 0, 0, false
+	}
+	w, err := strconv.Atoi(weekPart)
+	if err != nil {
+		return 0, 0, false
+	}
+	return y, w, true
+}
+
+// isoWeekStart returns the Monday 00:00 that begins the given ISO year/week.
+func isoWeekStart(year, week int) time.Time {
+	// Jan 4th always falls in ISO week 1.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)
+	start, _ := weekBounds(jan4)
+	return start.AddDate(0, 0, (week-1)*7)
+}
+
 type App struct {
-	DB DB
+	DB    DB
+	Store Store
 }
 
-func renderStopped(p *Project) {
-	fmt.Printf("%s %s %s\n", red.Render("Stopped:"), p.Name, p.prettyRefParen())
+func renderStopped(p *Project, t *Task) {
+	fmt.Printf("%s %s %s\n", red.Render("Stopped:"), taskLabel(p, t), p.prettyRefParen())
 	fmt.Println()
 	renderStats(p, true)
 }
 
-func renderStarted(p *Project) {
-	fmt.Printf("%s %s %s\n", green.Render("Started:"), p.Name, p.prettyRefParen())
+func renderStarted(p *Project, t *Task) {
+	fmt.Printf("%s %s %s\n", green.Render("Started:"), taskLabel(p, t), p.prettyRefParen())
 	if !p.JustCreated {
 		fmt.Println()
 		renderStats(p, false)
@@ -430,11 +1127,20 @@ func renderCurrent(p *Project) {
 	} else {
 		state = red.Render("Stopped:")
 	}
-	fmt.Printf("%s %s %s\n", state, p.Name, p.prettyRefParen())
+	t, _ := p.InProgressTask()
+	fmt.Printf("%s %s %s\n", state, taskLabel(p, t), p.prettyRefParen())
 	fmt.Println()
 	renderStats(p, true)
 }
 
+// taskLabel renders a project name, or "project > task" when t is non-nil.
+func taskLabel(p *Project, t *Task) string {
+	if t == nil {
+		return p.Name
+	}
+	return fmt.Sprintf("%s > %s", p.Name, t.Name)
+}
+
 func renderStats(p *Project, duration bool) {
 	var rows [][]string
 	if duration {
@@ -445,11 +1151,32 @@ func renderStats(p *Project, duration bool) {
 		[]string{"Total", p.TotalFormatted()},
 	)
 	fmt.Println(grid(rows...))
+
+	if len(p.Tasks) > 0 {
+		fmt.Println()
+		renderTaskStats(p)
+	}
+}
+
+func renderTaskStats(p *Project) {
+	header := []string{"Task", "This Week", "Total"}
+	for i, h := range header {
+		header[i] = white.Render(h)
+	}
+	rows := [][]string{header}
+	for _, t := range p.Tasks {
+		rows = append(rows, []string{
+			t.Name,
+			cyan.Render(formatDuration(t.ThisWeek())),
+			cyan.Render(formatDuration(t.Total())),
+		})
+	}
+	fmt.Println(grid(rows...))
 }
 
 func (a *App) GetOrCreateProject(ref int, newProjectName string) (*Project, error) {
 	if newProjectName != "" {
-		return a.CreateProject(newProjectName), nil
+		return a.CreateProject(newProjectName)
 	}
 	p, err := a.ProjectByRef(ref)
 	if err == nil {
@@ -458,24 +1185,227 @@ func (a *App) GetOrCreateProject(ref int, newProjectName string) (*Project, erro
 	return nil, err
 }
 
-func (a *App) CreateProject(name string) *Project {
+func (a *App) CreateProject(name string) (*Project, error) {
 	p := &Project{Name: name, JustCreated: true}
 	a.DB.CreateProject(p)
-	return p
+	if err := a.Store.SaveProject(p); err != nil {
+		return nil, fmt.Errorf("save project: %w", err)
+	}
+	return p, nil
 }
 
-func (a *App) Stop(p *Project) bool {
-	e, ok := p.LastEntry()
-	if !ok {
-		return false
+// GetOrCreateTask returns the task with the given name on p, creating and persisting it if it
+// doesn't exist yet.
+func (a *App) GetOrCreateTask(p *Project, name string) (*Task, error) {
+	t := p.GetOrCreateTask(name)
+	if err := a.Store.SaveProject(p); err != nil {
+		return nil, fmt.Errorf("save project: %w", err)
 	}
-	e.End = time.Now()
-	p.Entries[len(p.Entries)-1] = e
-	return true
+	return t, nil
 }
 
-func (a *App) Start(p *Project) {
-	p.Entries = append(p.Entries, Entry{Start: time.Now()})
+// ResolveStartTarget parses an argument of the form "<project>" or "<project>:<task>" into the
+// project it refers to (looked up by ref, or created if it's a new name) and, if a task part was
+// given, the task within that project (looked up by name, or created if it doesn't exist yet).
+func (a *App) ResolveStartTarget(arg string) (*Project, *Task, error) {
+	projectArg, taskArg, hasTask := strings.Cut(arg, ":")
+
+	ref, err := strconv.Atoi(projectArg)
+	name := projectArg
+	if err == nil {
+		name = ""
+	} else {
+		ref = 0
+	}
+
+	p, err := a.GetOrCreateProject(ref, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !hasTask {
+		return p, nil, nil
+	}
+
+	t, err := a.GetOrCreateTask(p, taskArg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, t, nil
+}
+
+// resolveProjectTask parses an argument of the form "<project-ref>" or "<project-ref>:<task>"
+// into the project and, if given, task it refers to. Unlike ResolveStartTarget, it never creates
+// a project or task - both must already exist.
+func (a *App) resolveProjectTask(arg string) (*Project, *Task, error) {
+	refArg, taskArg, hasTask := strings.Cut(arg, ":")
+
+	ref, err := strconv.Atoi(refArg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse project ref: %w", err)
+	}
+	p, err := a.ProjectByRef(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hasTask {
+		return p, nil, nil
+	}
+
+	for _, t := range p.Tasks {
+		if t.Name == taskArg {
+			return p, t, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("task %q not found", taskArg)
+}
+
+// AddEntry inserts a new completed entry with the given start and end times into p, or into t if
+// it's non-nil, rejecting it if it would overlap with any existing entry belonging to p or one of
+// its tasks.
+func (a *App) AddEntry(p *Project, t *Task, start, end time.Time, meta EntryMeta) error {
+	e := Entry{Start: start, End: end}
+	meta.apply(&e)
+	if err := p.InsertEntry(t, e); err != nil {
+		return err
+	}
+	if err := a.Store.AppendEntry(p, t, entryPtr(p, t, start)); err != nil {
+		return fmt.Errorf("append entry: %w", err)
+	}
+	return nil
+}
+
+// EditEntry updates the start and end times of the entry at index within p, or within t if it's
+// non-nil, rejecting the change if it would overlap with any other entry belonging to p or one of
+// its tasks.
+func (a *App) EditEntry(p *Project, t *Task, index int, start, end time.Time) error {
+	entries := p.Entries
+	if t != nil {
+		entries = t.Entries
+	}
+	if index < 0 || index >= len(entries) {
+		return fmt.Errorf("entry %d not found", index)
+	}
+
+	e := entries[index]
+	e.Start = start
+	e.End = end
+	if err := p.UpdateEntry(t, index, e); err != nil {
+		return err
+	}
+	if err := a.Store.UpdateEntry(p, t, entryPtr(p, t, start)); err != nil {
+		return fmt.Errorf("update entry: %w", err)
+	}
+	return nil
+}
+
+// entryPtr returns a pointer to the entry starting at start within p, or within t if it's
+// non-nil.
+func entryPtr(p *Project, t *Task, start time.Time) *Entry {
+	entries := p.Entries
+	if t != nil {
+		entries = t.Entries
+	}
+	for i := range entries {
+		if entries[i].Start.Equal(start) {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+func taskEqual(t1, t2 *Task) bool {
+	if t1 == nil || t2 == nil {
+		return t1 == t2
+	}
+	return t1.ID == t2.ID
+}
+
+// EntryMeta carries the optional description and tags attached to an entry as it's stopped.
+type EntryMeta struct {
+	Description string
+	Tags        []string
+}
+
+func (m EntryMeta) apply(e *Entry) {
+	if m.Description != "" {
+		e.Description = m.Description
+	}
+	if len(m.Tags) > 0 {
+		e.Tags = m.Tags
+	}
+}
+
+// Stop stops whichever entry is currently in progress for p - either one of its own entries, or
+// one belonging to a task - setting its end time to at, and reports which task (if any) it
+// stopped.
+func (a *App) Stop(p *Project, meta EntryMeta, at time.Time) (*Task, bool, error) {
+	if e, ok := p.LastEntry(); ok && e.InProgress() {
+		e.End = at
+		meta.apply(&e)
+		p.Entries[len(p.Entries)-1] = e
+		if err := a.Store.UpdateEntry(p, nil, &e); err != nil {
+			return nil, true, fmt.Errorf("update entry: %w", err)
+		}
+		return nil, true, nil
+	}
+	for _, t := range p.Tasks {
+		if e, ok := t.LastEntry(); ok && e.InProgress() {
+			e.End = at
+			meta.apply(&e)
+			t.Entries[len(t.Entries)-1] = e
+			if err := a.Store.UpdateEntry(p, t, &e); err != nil {
+				return t, true, fmt.Errorf("update entry: %w", err)
+			}
+			return t, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// Start starts a new entry with the given start time on p, or on t if it's non-nil.
+func (a *App) Start(p *Project, t *Task, at time.Time) error {
+	e := Entry{Start: at}
+	var ptr *Entry
+	if t != nil {
+		t.Entries = append(t.Entries, e)
+		ptr = &t.Entries[len(t.Entries)-1]
+	} else {
+		p.Entries = append(p.Entries, e)
+		ptr = &p.Entries[len(p.Entries)-1]
+	}
+	if err := a.Store.AppendEntry(p, t, ptr); err != nil {
+		return fmt.Errorf("append entry: %w", err)
+	}
+	return nil
+}
+
+// SetInProgressDescription appends desc to the description of whichever entry is currently in
+// progress for p, whether it belongs to the project itself or one of its tasks. It's a no-op if
+// nothing is in progress.
+func (a *App) SetInProgressDescription(p *Project, desc string) error {
+	if e, ok := p.LastEntry(); ok && e.InProgress() {
+		e.Description = appendNote(e.Description, desc)
+		p.Entries[len(p.Entries)-1] = e
+		return a.Store.UpdateEntry(p, nil, &e)
+	}
+	for _, t := range p.Tasks {
+		if e, ok := t.LastEntry(); ok && e.InProgress() {
+			e.Description = appendNote(e.Description, desc)
+			t.Entries[len(t.Entries)-1] = e
+			return a.Store.UpdateEntry(p, t, &e)
+		}
+	}
+	return nil
+}
+
+// appendNote joins an additional note onto an entry's existing description, so repeated `tk
+// note` calls accumulate instead of clobbering each other.
+func appendNote(existing, note string) string {
+	if existing == "" {
+		return note
+	}
+	return existing + "; " + note
 }
 
 func (a *App) InProgressProject() (*Project, bool) {
@@ -501,27 +1431,58 @@ func (a *App) ProjectByRef(ref int) (*Project, error) {
 
 var ErrNoProjects = errors.New("no projects")
 
-func getDBPath() (string, error) {
-	if path := os.Getenv("TIMEKEEPER_DB"); path != "" {
-		return path, nil
-	}
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(home, ".timekeeper", "db.json"), nil
-}
-
 type Project struct {
 	ID          int       `json:"id"`
 	Ref         int       `json:"ref"`
 	Name        string    `json:"name"`
 	Entries     []Entry   `json:"entries"`
+	Tasks       []*Task   `json:"tasks,omitempty"`
+	TaskID      int       `json:"-"`
 	Archived    bool      `json:"archived"`
 	JustCreated bool      `json:"-"`
 	Created     time.Time `json:"created"`
 }
 
+// Task is a sub-project: work tracked against it rolls up into its parent Project's totals, but
+// is also reported on its own.
+type Task struct {
+	ID      int     `json:"id"`
+	Name    string  `json:"name"`
+	Entries []Entry `json:"entries"`
+}
+
+func (t *Task) LastEntry() (Entry, bool) {
+	if len(t.Entries) == 0 {
+		return Entry{}, false
+	}
+	return t.Entries[len(t.Entries)-1], true
+}
+
+func (t Task) Total() time.Duration {
+	var total time.Duration
+	for _, e := range t.Entries {
+		total += e.Duration()
+	}
+	return total
+}
+
+func (t Task) ThisWeek() time.Duration {
+	start, end := weekBounds(time.Now())
+	return t.DurationBetween(start, end)
+}
+
+// DurationBetween sums the duration of entries starting in [start, end).
+func (t Task) DurationBetween(start, end time.Time) time.Duration {
+	var total time.Duration
+	for _, e := range t.Entries {
+		if e.Start.Before(start) || !e.Start.Before(end) {
+			continue
+		}
+		total += e.Duration()
+	}
+	return total
+}
+
 func (p Project) prettyRef() string {
 	if p.Ref == p.ID {
 		return cyan.Render(strconv.Itoa(p.Ref))
@@ -539,26 +1500,146 @@ func (p *Project) prettyRefParen() string {
 	return fmt.Sprintf("(ref=%s id=%s)", cyan.Render(strconv.Itoa(p.Ref)), cyan.Render(strconv.Itoa(p.ID)))
 }
 
-func (p *Project) Equal(other *Project) bool {
-	if p == nil || other == nil {
-		return p == other
+// GetOrCreateTask returns the task with the given name, creating it if it doesn't exist yet.
+func (p *Project) GetOrCreateTask(name string) *Task {
+	for _, t := range p.Tasks {
+		if t.Name == name {
+			return t
+		}
+	}
+	p.TaskID++
+	t := &Task{ID: p.TaskID, Name: name}
+	p.Tasks = append(p.Tasks, t)
+	return t
+}
+
+// HasTag reports whether any entry belonging to p or one of its tasks carries the given tag.
+func (p *Project) HasTag(tag string) bool {
+	for _, e := range p.Entries {
+		if slices.Contains(e.Tags, tag) {
+			return true
+		}
+	}
+	for _, t := range p.Tasks {
+		for _, e := range t.Entries {
+			if slices.Contains(e.Tags, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// InsertEntry adds e to p, or to t if it's non-nil, rejecting it if it overlaps with any existing
+// entry belonging to p or one of its tasks, then keeps the slice sorted by start time.
+func (p *Project) InsertEntry(t *Task, e Entry) error {
+	if err := p.validateNoOverlap(t, -1, e); err != nil {
+		return err
+	}
+	if t != nil {
+		t.Entries = append(t.Entries, e)
+		sortEntries(t.Entries)
+		return nil
+	}
+	p.Entries = append(p.Entries, e)
+	sortEntries(p.Entries)
+	return nil
+}
+
+// UpdateEntry replaces the entry at index within p, or within t if it's non-nil, with e,
+// rejecting the change if it would overlap with any other entry belonging to p or one of its
+// tasks, then keeps the slice sorted by start time.
+func (p *Project) UpdateEntry(t *Task, index int, e Entry) error {
+	entries := p.Entries
+	if t != nil {
+		entries = t.Entries
+	}
+	if index < 0 || index >= len(entries) {
+		return fmt.Errorf("entry %d not found", index)
+	}
+	if err := p.validateNoOverlap(t, index, e); err != nil {
+		return err
+	}
+	entries[index] = e
+	sortEntries(entries)
+	return nil
+}
+
+// validateNoOverlap reports an error if e overlaps with any entry belonging to p or one of its
+// tasks, other than the one at index within t (or within p's own entries, if t is nil). Pass
+// index -1 to check against every existing entry.
+func (p *Project) validateNoOverlap(t *Task, index int, e Entry) error {
+	check := func(entries []Entry, self *Task) error {
+		for i, existing := range entries {
+			if self == t && i == index {
+				continue
+			}
+			if overlaps(e, existing) {
+				return fmt.Errorf("overlaps with entry starting %s", existing.Start.Format("2006-01-02 15:04"))
+			}
+		}
+		return nil
+	}
+	if err := check(p.Entries, nil); err != nil {
+		return err
+	}
+	for _, pt := range p.Tasks {
+		if err := check(pt.Entries, pt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortEntries(entries []Entry) {
+	slices.SortFunc(entries, func(a, b Entry) int { return a.Start.Compare(b.Start) })
+}
+
+// overlaps reports whether a and b cover any of the same time range. An entry still in progress
+// (zero End) is treated as open-ended.
+func overlaps(a, b Entry) bool {
+	aStart, aEnd := entryRange(a)
+	bStart, bEnd := entryRange(b)
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
+
+func entryRange(e Entry) (time.Time, time.Time) {
+	end := e.End
+	if end.IsZero() {
+		end = farFuture()
 	}
-	return p.ID == other.ID &&
-		p.Name == other.Name &&
-		p.Archived == other.Archived &&
-		slices.Equal(p.Entries, other.Entries)
+	return e.Start, end
 }
 
-func (p Project) Clone() *Project {
-	p.Entries = slices.Clone(p.Entries)
-	return &p
+// AllEntries returns every entry belonging to p or one of its tasks, sorted by start time.
+func (p *Project) AllEntries() []Entry {
+	all := slices.Clone(p.Entries)
+	for _, t := range p.Tasks {
+		all = append(all, t.Entries...)
+	}
+	slices.SortFunc(all, func(a, b Entry) int { return a.Start.Compare(b.Start) })
+	return all
 }
 
 func (p *Project) InProgress() bool {
-	e, ok := p.LastEntry()
+	e, ok := p.LastEntryAny()
 	return ok && e.InProgress()
 }
 
+// InProgressTask reports which task (if any) currently has an entry in progress. A nil task with
+// ok true means the project itself (rather than one of its tasks) is in progress.
+func (p *Project) InProgressTask() (*Task, bool) {
+	if e, ok := p.LastEntry(); ok && e.InProgress() {
+		return nil, true
+	}
+	for _, t := range p.Tasks {
+		if e, ok := t.LastEntry(); ok && e.InProgress() {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
 func (p *Project) LastEntry() (Entry, bool) {
 	if len(p.Entries) == 0 {
 		return Entry{}, false
@@ -566,8 +1647,20 @@ func (p *Project) LastEntry() (Entry, bool) {
 	return p.Entries[len(p.Entries)-1], true
 }
 
+// LastEntryAny returns the most recently started entry across the project's own entries and
+// those of its tasks.
+func (p *Project) LastEntryAny() (Entry, bool) {
+	best, ok := p.LastEntry()
+	for _, t := range p.Tasks {
+		if e, tok := t.LastEntry(); tok && (!ok || e.Start.After(best.Start)) {
+			best, ok = e, true
+		}
+	}
+	return best, ok
+}
+
 func (p Project) DurationFormatted() string {
-	e, ok := p.LastEntry()
+	e, ok := p.LastEntryAny()
 	if !ok {
 		return cyan.Render("-")
 	}
@@ -575,21 +1668,40 @@ func (p Project) DurationFormatted() string {
 }
 
 func (p Project) ThisWeek() time.Duration {
-	_, week := time.Now().ISOWeek()
+	start, end := weekBounds(time.Now())
+	return p.DurationBetween(start, end)
+}
 
+// DurationBetween sums the duration of entries (including those of p's tasks) starting in
+// [start, end). This replaces comparing ISO week numbers directly, which breaks at year
+// boundaries (week 1 of one year and week 1 of the next both report week number 1).
+func (p Project) DurationBetween(start, end time.Time) time.Duration {
 	var total time.Duration
 	for _, e := range p.Entries {
-		_, w := e.Start.ISOWeek()
-		if w == week {
-			total += e.Duration()
+		if e.Start.Before(start) || !e.Start.Before(end) {
+			continue
 		}
+		total += e.Duration()
+	}
+	for _, t := range p.Tasks {
+		total += t.DurationBetween(start, end)
 	}
-
 	return total
 }
 
+// weekBounds returns the start (Monday 00:00) and end (the following Monday 00:00) of the week
+// containing t.
+func weekBounds(t time.Time) (time.Time, time.Time) {
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		weekday = 7 // ISO weeks start on Monday; treat Sunday as day 7, not day 0.
+	}
+	monday := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, -(weekday - 1))
+	return monday, monday.AddDate(0, 0, 7)
+}
+
 func (p Project) ThisWeekFormatted() string {
-	if len(p.Entries) == 0 {
+	if len(p.Entries) == 0 && len(p.Tasks) == 0 {
 		return cyan.Render("-")
 	}
 	return cyan.Render(formatDuration(p.ThisWeek()))
@@ -600,18 +1712,21 @@ func (p Project) Total() time.Duration {
 	for _, e := range p.Entries {
 		total += e.Duration()
 	}
+	for _, t := range p.Tasks {
+		total += t.Total()
+	}
 	return total
 }
 
 func (p Project) TotalFormatted() string {
-	if len(p.Entries) == 0 {
+	if len(p.Entries) == 0 && len(p.Tasks) == 0 {
 		return cyan.Render("-")
 	}
 	return cyan.Render(formatDuration(p.Total()))
 }
 
 func (p Project) LastStartFormatted() string {
-	e, ok := p.LastEntry()
+	e, ok := p.LastEntryAny()
 	if !ok {
 		return cyan.Render("-")
 	}
@@ -619,8 +1734,14 @@ func (p Project) LastStartFormatted() string {
 }
 
 type Entry struct {
-	Start time.Time `json:"start"`
-	End   time.Time `json:"end"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Description string    `json:"description,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+
+	// ID identifies the entry's backing row for stores (e.g. sqliteStore) that persist entries
+	// individually rather than as part of a whole-project blob. Unused by jsonStore.
+	ID int64 `json:"-"`
 }
 
 func (e Entry) Duration() time.Duration {
@@ -634,6 +1755,27 @@ func (e Entry) InProgress() bool {
 	return e.End.IsZero()
 }
 
+// formatEntryEnd formats e's end time, or "-" if it's still in progress.
+func formatEntryEnd(e Entry) string {
+	if e.End.IsZero() {
+		return "-"
+	}
+	return e.End.Format("2006-01-02 15:04")
+}
+
+func splitTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 type DB struct {
 	ProjectID int        `json:"-"`
 	Projects  []*Project `json:"projects"`
@@ -650,6 +1792,9 @@ func (db *DB) Init() {
 	for _, p := range db.Projects {
 		p.Ref = p.ID
 		db.ProjectID = max(db.ProjectID, p.ID)
+		for _, t := range p.Tasks {
+			p.TaskID = max(p.TaskID, t.ID)
+		}
 	}
 
 	// For the first 10 projects, give them a reference number equal to their index in the list.
@@ -662,20 +1807,6 @@ func (db *DB) Init() {
 
 }
 
-func (db DB) Equal(other DB) bool {
-	return db.ProjectID == other.ProjectID &&
-		slices.EqualFunc(db.Projects, other.Projects, (*Project).Equal)
-}
-
-func (db DB) Clone() DB {
-	cloned := make([]*Project, len(db.Projects))
-	for i, p := range db.Projects {
-		cloned[i] = p.Clone()
-	}
-	db.Projects = cloned
-	return db
-}
-
 func (db *DB) ListProjects(all bool) []*Project {
 	if all {
 		return db.Projects
@@ -721,8 +1852,8 @@ func (p *Project) Compare(p2 *Project) int {
 		}
 		return -1
 	}
-	e1, ok1 := p.LastEntry()
-	e2, ok2 := p2.LastEntry()
+	e1, ok1 := p.LastEntryAny()
+	e2, ok2 := p2.LastEntryAny()
 
 	if !ok1 && !ok2 {
 		return p2.Created.Compare(p.Created)
@@ -737,58 +1868,6 @@ func (p *Project) Compare(p2 *Project) int {
 	return e2.Start.Compare(e1.Start)
 }
 
-func readDB() (DB, error) {
-	dbPath, err := getDBPath()
-	if err != nil {
-		return DB{}, err
-	}
-
-	f, err := os.Open(dbPath)
-	if errors.Is(err, os.ErrNotExist) {
-		return DB{}, nil
-	}
-	if err != nil {
-		return DB{}, err
-	}
-	defer f.Close()
-
-	var db DB
-	if err := json.NewDecoder(f).Decode(&db); err != nil {
-		return DB{}, err
-	}
-
-	db.Init()
-
-	return db, nil
-}
-
-func writeDB(db DB) error {
-	dbPath, err := getDBPath()
-	if err != nil {
-		return err
-	}
-
-	f, err := os.Create(dbPath)
-	if errors.Is(err, os.ErrNotExist) {
-		if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
-			return err
-		}
-		f, err = os.Create(dbPath)
-	}
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	enc := json.NewEncoder(f)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(db); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func grid(rows ...[]string) string {
 	t := table.New().
 		Headers(rows[0]...).
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+)
+
+// Store is the persistence backend for the time tracking database. The default is a single JSON
+// file (jsonStore); setting TIMEKEEPER_STORE=sqlite switches to a SQLite-backed store (see
+// sqliteStore) that writes entries append-only instead of rewriting the whole file on every
+// invocation.
+type Store interface {
+	// LoadProjects loads every project (with its tasks and entries) and initialises ref numbers
+	// and ID counters on the returned DB.
+	LoadProjects() (DB, error)
+
+	// SaveProject persists p's own fields and its tasks. Entries are persisted through
+	// AppendEntry and UpdateEntry; sqliteStore does not write them here.
+	SaveProject(p *Project) error
+
+	// AppendEntry persists a new entry (running or completed), either on p directly (t nil) or on t. It may
+	// set fields on e (e.g. a backing row ID) needed to target it in a later UpdateEntry call.
+	AppendEntry(p *Project, t *Task, e *Entry) error
+
+	// UpdateEntry persists changes to an entry previously passed to AppendEntry, either on p
+	// directly (t nil) or on t.
+	UpdateEntry(p *Project, t *Task, e *Entry) error
+
+	// RemoveProject deletes p and everything belonging to it.
+	RemoveProject(p *Project) error
+
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+// newStore builds the Store selected by the TIMEKEEPER_STORE environment variable, defaulting to
+// the JSON file store.
+func newStore() (Store, error) {
+	switch os.Getenv("TIMEKEEPER_STORE") {
+	case "sqlite":
+		dsn, err := getSQLiteDSN()
+		if err != nil {
+			return nil, err
+		}
+		return newSQLiteStore(dsn)
+	default:
+		dbPath, err := getDBPath()
+		if err != nil {
+			return nil, err
+		}
+		return &jsonStore{path: dbPath}, nil
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// jsonStore is the original, default persistence backend: the whole database lives in a single
+// JSON file, which is read in full on load and rewritten in full on every mutation.
+type jsonStore struct {
+	path string
+}
+
+func getDBPath() (string, error) {
+	if path := os.Getenv("TIMEKEEPER_DB"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".timekeeper", "db.json"), nil
+}
+
+func (s *jsonStore) LoadProjects() (DB, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DB{}, nil
+	}
+	if err != nil {
+		return DB{}, err
+	}
+	defer f.Close()
+
+	var db DB
+	if err := json.NewDecoder(f).Decode(&db); err != nil {
+		return DB{}, err
+	}
+
+	db.Init()
+
+	return db, nil
+}
+
+func (s *jsonStore) write(db DB) error {
+	f, err := os.Create(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+			return err
+		}
+		f, err = os.Create(s.path)
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(db)
+}
+
+// SaveProject rewrites the whole database file with p's current state merged in. There's no
+// cheaper way to persist a single project with a flat JSON file.
+func (s *jsonStore) SaveProject(p *Project) error {
+	db, err := s.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, existing := range db.Projects {
+		if existing.ID == p.ID {
+			db.Projects[i] = p
+			found = true
+			break
+		}
+	}
+	if !found {
+		db.Projects = append(db.Projects, p)
+	}
+
+	return s.write(db)
+}
+
+func (s *jsonStore) AppendEntry(p *Project, t *Task, e *Entry) error {
+	return s.SaveProject(p)
+}
+
+func (s *jsonStore) UpdateEntry(p *Project, t *Task, e *Entry) error {
+	return s.SaveProject(p)
+}
+
+func (s *jsonStore) RemoveProject(p *Project) error {
+	db, err := s.LoadProjects()
+	if err != nil {
+		return err
+	}
+
+	var projects []*Project
+	for _, existing := range db.Projects {
+		if existing.ID != p.ID {
+			projects = append(projects, existing)
+		}
+	}
+	db.Projects = projects
+
+	return s.write(db)
+}
+
+func (s *jsonStore) Close() error {
+	return nil
+}
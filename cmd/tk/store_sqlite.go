@@ -0,0 +1,265 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteStore persists projects, tasks and entries as proper tables, so starting or stopping an
+// entry is a single-row insert/update instead of a full rewrite of the database. Selected via
+// TIMEKEEPER_STORE=sqlite, with the database file chosen by TIMEKEEPER_DSN.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+func getSQLiteDSN() (string, error) {
+	if dsn := os.Getenv("TIMEKEEPER_DSN"); dsn != "" {
+		return dsn, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".timekeeper", "db.sqlite3"), nil
+}
+
+func newSQLiteStore(dsn string) (*sqliteStore, error) {
+	if dir := filepath.Dir(dsn); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	pragmas := []string{
+		`PRAGMA foreign_keys = ON`,
+		`PRAGMA journal_mode = WAL`,
+		`PRAGMA busy_timeout = 5000`,
+	}
+	for _, pragma := range pragmas {
+		if _, err := db.Exec(pragma); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	if err := initSQLiteSchema(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &sqliteStore{db: db}, nil
+}
+
+func initSQLiteSchema(db *sql.DB) error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS projects (
+			id       INTEGER PRIMARY KEY,
+			name     TEXT NOT NULL,
+			archived BOOLEAN NOT NULL DEFAULT 0,
+			created  DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS tasks (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+			name       TEXT NOT NULL,
+			UNIQUE(project_id, name)
+		)`,
+		`CREATE TABLE IF NOT EXISTS entries (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_id  INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+			task_id     INTEGER REFERENCES tasks(id) ON DELETE CASCADE,
+			start       DATETIME NOT NULL,
+			end         DATETIME,
+			description TEXT NOT NULL DEFAULT '',
+			tags        TEXT NOT NULL DEFAULT ''
+		)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sqliteStore) LoadProjects() (DB, error) {
+	var db DB
+	projectsByID := map[int]*Project{}
+
+	rows, err := s.db.Query(`SELECT id, name, archived, created FROM projects`)
+	if err != nil {
+		return DB{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := &Project{}
+		var archived bool
+		if err := rows.Scan(&p.ID, &p.Name, &archived, &p.Created); err != nil {
+			return DB{}, err
+		}
+		p.Archived = archived
+		projectsByID[p.ID] = p
+		db.Projects = append(db.Projects, p)
+	}
+	if err := rows.Err(); err != nil {
+		return DB{}, err
+	}
+
+	tasksByID := map[int64]*Task{}
+	taskRows, err := s.db.Query(`SELECT id, project_id, name FROM tasks`)
+	if err != nil {
+		return DB{}, err
+	}
+	defer taskRows.Close()
+
+	for taskRows.Next() {
+		var id int64
+		var projectID int
+		var name string
+		if err := taskRows.Scan(&id, &projectID, &name); err != nil {
+			return DB{}, err
+		}
+		p, ok := projectsByID[projectID]
+		if !ok {
+			continue
+		}
+		t := &Task{ID: int(id), Name: name}
+		p.Tasks = append(p.Tasks, t)
+		tasksByID[id] = t
+	}
+	if err := taskRows.Err(); err != nil {
+		return DB{}, err
+	}
+
+	entryRows, err := s.db.Query(
+		`SELECT id, project_id, task_id, start, end, description, tags FROM entries ORDER BY start`,
+	)
+	if err != nil {
+		return DB{}, err
+	}
+	defer entryRows.Close()
+
+	for entryRows.Next() {
+		var projectID int
+		var taskID sql.NullInt64
+		var end sql.NullTime
+		var tags string
+		e := Entry{}
+		if err := entryRows.Scan(&e.ID, &projectID, &taskID, &e.Start, &end, &e.Description, &tags); err != nil {
+			return DB{}, err
+		}
+		if end.Valid {
+			e.End = end.Time
+		}
+		if tags != "" {
+			e.Tags = strings.Split(tags, ",")
+		}
+
+		p, ok := projectsByID[projectID]
+		if !ok {
+			continue
+		}
+		if taskID.Valid {
+			if t, ok := tasksByID[taskID.Int64]; ok {
+				t.Entries = append(t.Entries, e)
+				continue
+			}
+		}
+		p.Entries = append(p.Entries, e)
+	}
+	if err := entryRows.Err(); err != nil {
+		return DB{}, err
+	}
+
+	db.Init()
+
+	return db, nil
+}
+
+func (s *sqliteStore) SaveProject(p *Project) error {
+	_, err := s.db.Exec(`
+		INSERT INTO projects (id, name, archived, created) VALUES (?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET name = excluded.name, archived = excluded.archived
+	`, p.ID, p.Name, p.Archived, p.Created)
+	if err != nil {
+		return fmt.Errorf("upsert project: %w", err)
+	}
+
+	for _, t := range p.Tasks {
+		if _, err := s.db.Exec(`
+			INSERT INTO tasks (project_id, name) VALUES (?, ?)
+			ON CONFLICT(project_id, name) DO NOTHING
+		`, p.ID, t.Name); err != nil {
+			return fmt.Errorf("upsert task %q: %w", t.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *sqliteStore) AppendEntry(p *Project, t *Task, e *Entry) error {
+	var taskID sql.NullInt64
+	if t != nil {
+		id, err := s.taskRowID(p.ID, t.Name)
+		if err != nil {
+			return fmt.Errorf("find task row: %w", err)
+		}
+		taskID = sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	res, err := s.db.Exec(`
+		INSERT INTO entries (project_id, task_id, start, end, description, tags) VALUES (?, ?, ?, ?, ?, ?)
+	`, p.ID, taskID, e.Start, nullTime(e.End), e.Description, strings.Join(e.Tags, ","))
+	if err != nil {
+		return fmt.Errorf("insert entry: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	e.ID = id
+
+	return nil
+}
+
+func (s *sqliteStore) UpdateEntry(p *Project, t *Task, e *Entry) error {
+	_, err := s.db.Exec(`
+		UPDATE entries SET start = ?, end = ?, description = ?, tags = ? WHERE id = ?
+	`, e.Start, nullTime(e.End), e.Description, strings.Join(e.Tags, ","), e.ID)
+	return err
+}
+
+func (s *sqliteStore) RemoveProject(p *Project) error {
+	_, err := s.db.Exec(`DELETE FROM projects WHERE id = ?`, p.ID)
+	return err
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *sqliteStore) taskRowID(projectID int, name string) (int64, error) {
+	var id int64
+	err := s.db.QueryRow(`SELECT id FROM tasks WHERE project_id = ? AND name = ?`, projectID, name).Scan(&id)
+	return id, err
+}
+
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/urfave/cli/v3"
+)
+
+func (a *App) tuiCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "tui",
+		Usage: "Launch an interactive dashboard",
+		Action: func(ctx context.Context, command *cli.Command) error {
+			_, err := tea.NewProgram(newTUIModel(a), tea.WithAltScreen()).Run()
+			return err
+		},
+	}
+}
+
+type tickMsg time.Time
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+// tuiModel is the Bubble Tea model behind `tk tui`. It reads and mutates the same App used by
+// the rest of the CLI, so every action (start, stop, new, archive) persists through a.Store
+// immediately, the same way the non-interactive commands do.
+type tuiModel struct {
+	app       *App
+	projects  []*Project
+	cursor    int
+	filtering bool
+	filter    string
+	err       error
+}
+
+func newTUIModel(a *App) tuiModel {
+	return tuiModel{
+		app:      a,
+		projects: a.DB.ListProjects(false),
+	}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return tick()
+}
+
+func (m tuiModel) visibleProjects() []*Project {
+	if m.filter == "" {
+		return m.projects
+	}
+	var out []*Project
+	for _, p := range m.projects {
+		if strings.Contains(strings.ToLower(p.Name), strings.ToLower(m.filter)) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (m *tuiModel) selected() *Project {
+	vp := m.visibleProjects()
+	if m.cursor < 0 || m.cursor >= len(vp) {
+		return nil
+	}
+	return vp[m.cursor]
+}
+
+func (m *tuiModel) selectProject(p *Project) {
+	for i, vp := range m.visibleProjects() {
+		if vp.ID == p.ID {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tickMsg:
+		return m, tick()
+
+	case tea.KeyMsg:
+		if m.filtering {
+			switch msg.Type {
+			case tea.KeyEsc:
+				m.filtering = false
+				m.filter = ""
+			case tea.KeyEnter:
+				m.filtering = false
+			case tea.KeyBackspace:
+				if len(m.filter) > 0 {
+					m.filter = m.filter[:len(m.filter)-1]
+				}
+			case tea.KeyRunes:
+				m.filter += string(msg.Runes)
+			}
+			return m, nil
+		}
+
+		m.err = nil
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down", "j":
+			if m.cursor < len(m.visibleProjects())-1 {
+				m.cursor++
+			}
+
+		case "/":
+			m.filtering = true
+			m.filter = ""
+
+		case "n":
+			p, err := m.app.CreateProject(fmt.Sprintf("project-%d", len(m.projects)+1))
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.projects = m.app.DB.ListProjects(false)
+			m.selectProject(p)
+
+		case "a":
+			if p := m.selected(); p != nil {
+				if !p.Archived && p.InProgress() {
+					if _, _, err := m.app.Stop(p, EntryMeta{}, time.Now()); err != nil {
+						m.err = err
+						return m, nil
+					}
+				}
+				p.Archived = !p.Archived
+				if err := m.app.Store.SaveProject(p); err != nil {
+					m.err = err
+					return m, nil
+				}
+				m.projects = m.app.DB.ListProjects(false)
+			}
+
+		case "s":
+			// Start persists through a.Store itself (it appends to the in-memory slice before
+			// calling AppendEntry), so the new entry is captured by both the JSON and SQLite
+			// backends without any extra write here.
+			p := m.selected()
+			if p == nil {
+				return m, nil
+			}
+			if ip, ok := m.app.InProgressProject(); ok {
+				if _, _, err := m.app.Stop(ip, EntryMeta{}, time.Now()); err != nil {
+					m.err = err
+					return m, nil
+				}
+				if ip.ID == p.ID {
+					return m, nil
+				}
+			}
+			if err := m.app.Start(p, nil, time.Now()); err != nil {
+				m.err = err
+			}
+
+		case "S":
+			if ip, ok := m.app.InProgressProject(); ok {
+				if _, _, err := m.app.Stop(ip, EntryMeta{}, time.Now()); err != nil {
+					m.err = err
+				}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m tuiModel) View() string {
+	var b strings.Builder
+
+	if m.filtering {
+		fmt.Fprintf(&b, "%s %s\n\n", white.Render("Filter:"), m.filter)
+	} else {
+		b.WriteString(white.Render("tk dashboard"))
+		b.WriteString("\n\n")
+	}
+
+	vp := m.visibleProjects()
+	for i, p := range vp {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		name := p.Name
+		if p.InProgress() {
+			name = green.Render(name) + "  " + p.DurationFormatted()
+		}
+		fmt.Fprintf(&b, "%s%s\n", cursor, name)
+	}
+	if len(vp) == 0 {
+		b.WriteString(grey.Render("No projects\n"))
+	}
+
+	if p := m.selected(); p != nil {
+		b.WriteString("\n")
+		b.WriteString(renderTUIDetail(p))
+	}
+
+	if m.err != nil {
+		fmt.Fprintf(&b, "\n%s\n", red.Render(m.err.Error()))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(grey.Render("s start/stop  S stop current  n new  a archive  / filter  q quit"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func renderTUIDetail(p *Project) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %s\n", white.Render("This week"), p.ThisWeekFormatted())
+	fmt.Fprintf(&b, "%s  %s\n", white.Render("Total"), p.TotalFormatted())
+	fmt.Fprintf(&b, "%s  %s\n", white.Render("Last 14 days"), sparkline(p))
+	return b.String()
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders a 14-character bar chart of p's daily duration, scaled to the busiest day in
+// the range.
+func sparkline(p *Project) string {
+	now := time.Now()
+	days := make([]time.Duration, 14)
+	var max time.Duration
+	for i := range days {
+		day := truncateDay(now.AddDate(0, 0, -(13 - i)))
+		d := p.DurationBetween(day, day.AddDate(0, 0, 1))
+		days[i] = d
+		if d > max {
+			max = d
+		}
+	}
+
+	var b strings.Builder
+	for _, d := range days {
+		if max == 0 {
+			b.WriteRune(sparkBlocks[0])
+			continue
+		}
+		idx := int(float64(d) / float64(max) * float64(len(sparkBlocks)-1))
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return cyan.Render(b.String())
+}